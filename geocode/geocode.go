@@ -0,0 +1,86 @@
+// Package geocode resolves free-text addresses to coordinates.
+package geocode
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Geocoder resolves an address to (longitude, latitude).
+type Geocoder interface {
+	Geocode(address string) (lon, lat float64, err error)
+}
+
+// NominatimGeocoder queries a Nominatim-compatible HTTP search endpoint
+// (https://nominatim.org/release-docs/latest/api/Search/), the default
+// backend for OpenStreetMap geocoding.
+type NominatimGeocoder struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewNominatimGeocoder returns a NominatimGeocoder pointed at the public
+// OpenStreetMap instance with a sane request timeout.
+func NewNominatimGeocoder() *NominatimGeocoder {
+	return &NominatimGeocoder{
+		BaseURL: "https://nominatim.openstreetmap.org/search",
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type nominatimResult struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// Geocode resolves address via the configured Nominatim endpoint, returning
+// the first match.
+func (n *NominatimGeocoder) Geocode(address string) (float64, float64, error) {
+	u, err := url.Parse(n.BaseURL)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid base URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("q", address)
+	q.Set("format", "json")
+	q.Set("limit", "1")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("User-Agent", "truss-normalizer")
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("geocoding request returned %s", resp.Status)
+	}
+
+	var results []nominatimResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return 0, 0, fmt.Errorf("decoding geocoding response: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, 0, fmt.Errorf("no results for %q", address)
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude %q: %w", results[0].Lat, err)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude %q: %w", results[0].Lon, err)
+	}
+	return lon, lat, nil
+}
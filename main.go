@@ -2,18 +2,39 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"os"
+
+	"github.com/brooksa321/truss-normalizer/geocode"
+	"github.com/brooksa321/truss-normalizer/output"
+	"github.com/brooksa321/truss-normalizer/pipeline"
 )
 
 func main() {
-	// Provide usage if all parameters aren't provided
-	if len(os.Args) < 3 {
-		fmt.Fprintln(os.Stderr, "Usage: normalizer <input.csv> <output.csv>")
+	configPath := flag.String("config", "", "path to a YAML/JSON pipeline rules file (optional; defaults to the built-in column order)")
+	sourceTZ := flag.String("source-tz", "America/Los_Angeles", "source timezone for the Timestamp column (ignored when -config is set)")
+	targetTZ := flag.String("target-tz", "America/New_York", "target timezone for the Timestamp column (ignored when -config is set)")
+	format := flag.String("format", "csv", "output format: csv, json, or ndjson")
+	geocodeAddresses := flag.Bool("geocode", false, "resolve the Address column to lat/lon and write a GeoJSON FeatureCollection instead of -format")
+	utf8Mode := flag.String("utf8-mode", "replace", "how to repair invalid UTF-8 in Address/Notes: replace, drop, or asciifold (ignored when -config is set)")
+	reportPath := flag.String("report", "", "path to write a newline-delimited JSON report of every row a rule had to act on (optional)")
+	strict := flag.Bool("strict", false, "exit nonzero if any row triggered a rule")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: normalizer [-config rules.yaml] [-source-tz tz] [-target-tz tz] [-format csv|json|ndjson] [-geocode] <input.csv> <output>")
+		os.Exit(1)
+	}
+	inputPath := args[0]
+	outputPath := args[1]
+
+	p, err := buildPipeline(*configPath, *sourceTZ, *targetTZ, *utf8Mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error building pipeline: %v\n", err)
 		os.Exit(1)
 	}
-	inputPath := os.Args[1]
-	outputPath := os.Args[2]
 
 	// Open input csv
 	f, err := os.Open(inputPath)
@@ -26,73 +47,87 @@ func main() {
 	r := csv.NewReader(f)
 	r.FieldsPerRecord = -1
 
-	records, err := r.ReadAll()
+	// Open output file
+	outFile, err := os.Create(outputPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input CSV: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
 		os.Exit(1)
 	}
+	defer outFile.Close()
 
-	// Process Timestamp
-	records, err = ProcessTimestamp(records)
+	w, err := buildRowWriter(outFile, *format, *geocodeAddresses)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting timestamps: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error building output writer: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Process Zip Codes
-	records, err = ProcessZIPs(records)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error normalizing ZIPs: %v\n", err)
-		os.Exit(1)
+	var report *pipeline.Report
+	if *reportPath != "" || *strict {
+		report = &pipeline.Report{}
 	}
 
-	// Process First Names
-	records, err = ProcessFirstName(records)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error processing FullName: %v\n", err)
+	if err := pipeline.NewRowProcessor(p).Run(r, w, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running pipeline: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Process Address
-	records, err = ProcessAddress(records)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error validating Address column: %v\n", err)
-		os.Exit(1)
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Process Durations
-	records, err = ProcessDurations(records)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error converting durations: %v\n", err)
+	if *strict && report.RowCount() > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d row(s) triggered a rule under -strict\n", report.RowCount())
 		os.Exit(1)
 	}
+}
 
-	// Process Total Duration
-	records, err = ProcessTotalDuration(records)
+// writeReport writes report as newline-delimited JSON to path.
+func writeReport(path string, report *pipeline.Report) error {
+	f, err := os.Create(path)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fixing TotalDuration: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("creating report file: %w", err)
 	}
+	defer f.Close()
+	return report.WriteNDJSON(f)
+}
 
-	// Process Notes
-	records, err = ProcessNotes(records)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error validating Notes column: %v\n", err)
-		os.Exit(1)
+// buildRowWriter returns the RowWriter matching -geocode and -format:
+// geocode always wins, producing a GeoJSON FeatureCollection; otherwise
+// format selects between CSV, JSON, and NDJSON.
+func buildRowWriter(w *os.File, format string, geocodeAddresses bool) (pipeline.RowWriter, error) {
+	if geocodeAddresses {
+		return output.NewGeoJSONWriter(w, geocode.NewNominatimGeocoder()), nil
 	}
 
-	// Write output csv
-	outFile, err := os.Create(outputPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
-		os.Exit(1)
+	switch format {
+	case "csv":
+		return pipeline.NewCSVRowWriter(csv.NewWriter(w)), nil
+	case "json":
+		return output.NewJSONWriter(w, false), nil
+	case "ndjson":
+		return output.NewJSONWriter(w, true), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want csv, json, or ndjson)", format)
 	}
-	defer outFile.Close()
+}
 
-	w := csv.NewWriter(outFile)
-	w.WriteAll(records)
-	if err := w.Error(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing output CSV: %v\n", err)
-		os.Exit(1)
+// buildPipeline returns the default hardcoded pipeline (with sourceTZ/targetTZ
+// and utf8Mode applied), or a config-driven one when configPath is set.
+func buildPipeline(configPath, sourceTZ, targetTZ, utf8Mode string) (*pipeline.Pipeline, error) {
+	if configPath == "" {
+		mode, err := pipeline.ParseMode(utf8Mode)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.DefaultWithOptions(sourceTZ, targetTZ, mode), nil
+	}
+
+	cfg, err := pipeline.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
 	}
+	return pipeline.Build(cfg)
 }
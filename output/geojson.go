@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/brooksa321/truss-normalizer/geocode"
+)
+
+// GeoJSONWriter geocodes the Address column of each row via a geocode.Geocoder
+// and writes the full set as a GeoJSON FeatureCollection.
+//
+// A FeatureCollection is a single JSON document, so rows are buffered and
+// the document is only written on Flush.
+type GeoJSONWriter struct {
+	w            io.Writer
+	geocoder     geocode.Geocoder
+	header       []string
+	addressIndex int
+	features     []feature
+}
+
+type feature struct {
+	Type       string            `json:"type"`
+	Geometry   geometry          `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type geometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// NewGeoJSONWriter wraps w as a RowWriter that geocodes each row's Address
+// column using geocoder.
+func NewGeoJSONWriter(w io.Writer, geocoder geocode.Geocoder) *GeoJSONWriter {
+	return &GeoJSONWriter{w: w, geocoder: geocoder}
+}
+
+func (g *GeoJSONWriter) WriteHeader(header []string) error {
+	g.header = header
+	g.addressIndex = -1
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), "Address") {
+			g.addressIndex = i
+			break
+		}
+	}
+	if g.addressIndex == -1 {
+		return fmt.Errorf("geocode: Address column not found")
+	}
+	return nil
+}
+
+func (g *GeoJSONWriter) WriteRow(row []string) error {
+	var coords [2]float64
+	if g.addressIndex < len(row) {
+		lon, lat, err := g.geocoder.Geocode(row[g.addressIndex])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not geocode %q: %v\n", row[g.addressIndex], err)
+		} else {
+			coords = [2]float64{lon, lat}
+		}
+	}
+
+	g.features = append(g.features, feature{
+		Type:       "Feature",
+		Geometry:   geometry{Type: "Point", Coordinates: coords},
+		Properties: rowObject(g.header, row),
+	})
+	return nil
+}
+
+func (g *GeoJSONWriter) Flush() error {
+	data, err := json.Marshal(featureCollection{Type: "FeatureCollection", Features: g.features})
+	if err != nil {
+		return fmt.Errorf("marshaling FeatureCollection: %w", err)
+	}
+	_, err = g.w.Write(data)
+	return err
+}
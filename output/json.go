@@ -0,0 +1,75 @@
+// Package output formats transformed CSV rows as CSV-adjacent output
+// formats: plain JSON, newline-delimited JSON, or a geocoded GeoJSON
+// FeatureCollection.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONWriter writes each row as a JSON object keyed by header name. When
+// NDJSON is false it emits a single JSON array; when true it emits one
+// object per line.
+type JSONWriter struct {
+	w      io.Writer
+	ndjson bool
+	header []string
+	wrote  bool
+}
+
+// NewJSONWriter wraps w as a RowWriter producing JSON or NDJSON.
+func NewJSONWriter(w io.Writer, ndjson bool) *JSONWriter {
+	return &JSONWriter{w: w, ndjson: ndjson}
+}
+
+func (j *JSONWriter) WriteHeader(header []string) error {
+	j.header = header
+	if j.ndjson {
+		return nil
+	}
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *JSONWriter) WriteRow(row []string) error {
+	data, err := json.Marshal(rowObject(j.header, row))
+	if err != nil {
+		return fmt.Errorf("marshaling row: %w", err)
+	}
+
+	if j.ndjson {
+		_, err := fmt.Fprintf(j.w, "%s\n", data)
+		return err
+	}
+
+	if j.wrote {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.wrote = true
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *JSONWriter) Flush() error {
+	if j.ndjson {
+		return nil
+	}
+	_, err := io.WriteString(j.w, "]")
+	return err
+}
+
+// rowObject zips header and row into a JSON-friendly map, keyed by column
+// name. Columns without a value in row (short rows) are omitted.
+func rowObject(header, row []string) map[string]string {
+	obj := make(map[string]string, len(header))
+	for i, h := range header {
+		if i < len(row) {
+			obj[h] = row[i]
+		}
+	}
+	return obj
+}
@@ -0,0 +1,151 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleConfig declares a single transformer to run, its target column, and
+// any per-column parameters it accepts.
+type RuleConfig struct {
+	Name   string            `yaml:"name" json:"name"`
+	Column string            `yaml:"column" json:"column"`
+	Params map[string]string `yaml:"params" json:"params"`
+}
+
+// Config is the top-level shape of a rules file: an ordered list of rules.
+type Config struct {
+	Rules []RuleConfig `yaml:"rules" json:"rules"`
+}
+
+// LoadConfig reads a Config from path, detecting YAML vs JSON from the file
+// extension (.json is treated as JSON; everything else as YAML).
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg Config
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing JSON config: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing YAML config: %w", err)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// Build constructs a Pipeline from a Config, in rule order.
+func Build(cfg *Config) (*Pipeline, error) {
+	transformers := make([]Transformer, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		t, err := buildRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		transformers = append(transformers, t)
+	}
+	return New(transformers...), nil
+}
+
+func buildRule(r RuleConfig) (Transformer, error) {
+	switch r.Name {
+	case "timestamp":
+		var layouts []string
+		if l, ok := r.Params["layouts"]; ok {
+			layouts = strings.Split(l, ",")
+		} else if l, ok := r.Params["layout"]; ok {
+			layouts = []string{l}
+		}
+		return &TimestampTransformer{
+			Column:   orDefault(r.Column, "Timestamp"),
+			SourceTZ: orDefault(r.Params["source_tz"], "America/Los_Angeles"),
+			TargetTZ: orDefault(r.Params["target_tz"], "America/New_York"),
+			Layouts:  layouts,
+		}, nil
+
+	case "zip":
+		width := 5
+		if w, ok := r.Params["width"]; ok {
+			parsed, err := strconv.Atoi(w)
+			if err != nil {
+				return nil, fmt.Errorf("invalid width %q: %w", w, err)
+			}
+			width = parsed
+		}
+		return &ZIPTransformer{Column: orDefault(r.Column, "ZIP"), Width: width}, nil
+
+	case "firstname":
+		return &FirstNameTransformer{
+			Column: orDefault(r.Column, "FullName"),
+			Case:   orDefault(r.Params["case"], "upper"),
+		}, nil
+
+	case "address":
+		mode, err := ParseMode(r.Params["mode"])
+		if err != nil {
+			return nil, err
+		}
+		return &AddressTransformer{Column: orDefault(r.Column, "Address"), Mode: mode}, nil
+
+	case "durations":
+		cols, err := splitColumns(r.Params["columns"], []string{"FooDuration", "BarDuration"})
+		if err != nil {
+			return nil, err
+		}
+		return &DurationsTransformer{Columns: cols}, nil
+
+	case "total_duration":
+		cols, err := splitColumns(r.Params["columns"], []string{"FooDuration", "BarDuration"})
+		if err != nil {
+			return nil, err
+		}
+		return &TotalDurationTransformer{
+			Columns: cols,
+			Output:  orDefault(r.Column, "TotalDuration"),
+		}, nil
+
+	case "notes":
+		mode, err := ParseMode(r.Params["mode"])
+		if err != nil {
+			return nil, err
+		}
+		return &NotesTransformer{Column: orDefault(r.Column, "Notes"), Mode: mode}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown rule name %q", r.Name)
+	}
+}
+
+func orDefault(val, fallback string) string {
+	if val == "" {
+		return fallback
+	}
+	return val
+}
+
+func splitColumns(val string, fallback []string) ([]string, error) {
+	if val == "" {
+		return fallback, nil
+	}
+	var cols []string
+	for _, c := range strings.Split(val, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			return nil, fmt.Errorf("invalid columns list %q", val)
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
@@ -0,0 +1,73 @@
+// Package pipeline provides a pluggable, ordered set of column transformers
+// that operate on CSV records one row at a time.
+package pipeline
+
+import "fmt"
+
+// RowFunc mutates a single CSV record in place. It is bound to a fixed
+// column index (or set of indices), resolved once against the header, so it
+// carries no per-row lookup cost. rowNum is the 1-based input data row
+// number, for Report entries. report may be nil, in which case reporting is
+// skipped.
+type RowFunc func(row []string, rowNum int, report *Report) error
+
+// Transformer is a single named transformation. Bind resolves it against a
+// header row and returns a RowFunc ready to apply to every data row.
+type Transformer interface {
+	Name() string
+	Bind(header []string) (RowFunc, error)
+}
+
+// Pipeline runs an ordered list of Transformers.
+type Pipeline struct {
+	Transformers []Transformer
+}
+
+// New builds a Pipeline from an ordered list of Transformers.
+func New(transformers ...Transformer) *Pipeline {
+	return &Pipeline{Transformers: transformers}
+}
+
+// Bind resolves every Transformer against header, in order, so the result
+// can be applied to each data row without re-resolving column indices.
+func (p *Pipeline) Bind(header []string) ([]RowFunc, error) {
+	fns := make([]RowFunc, 0, len(p.Transformers))
+	for _, t := range p.Transformers {
+		fn, err := t.Bind(header)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Name(), err)
+		}
+		fns = append(fns, fn)
+	}
+	return fns, nil
+}
+
+// Default returns the pipeline matching the original hardcoded
+// Timestamp->ZIP->FirstName->Address->Durations->TotalDuration->Notes order,
+// converting Timestamp from America/Los_Angeles to America/New_York and
+// repairing invalid UTF-8 by substituting U+FFFD.
+func Default() *Pipeline {
+	return DefaultWithOptions("America/Los_Angeles", "America/New_York", Replace)
+}
+
+// DefaultWithOptions is Default but with the Timestamp column's source and
+// target timezones and the Address/Notes UTF-8 repair Mode overridden, e.g.
+// from CLI flags.
+func DefaultWithOptions(sourceTZ, targetTZ string, utf8Mode Mode) *Pipeline {
+	return New(
+		&TimestampTransformer{
+			Column:   "Timestamp",
+			SourceTZ: sourceTZ,
+			TargetTZ: targetTZ,
+		},
+		&ZIPTransformer{Column: "ZIP", Width: 5},
+		&FirstNameTransformer{Column: "FullName", Case: "upper"},
+		&AddressTransformer{Column: "Address", Mode: utf8Mode},
+		&DurationsTransformer{Columns: []string{"FooDuration", "BarDuration"}},
+		&TotalDurationTransformer{
+			Columns: []string{"FooDuration", "BarDuration"},
+			Output:  "TotalDuration",
+		},
+		&NotesTransformer{Column: "Notes", Mode: utf8Mode},
+	)
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Action describes what a Transformer did with a value it couldn't process
+// cleanly.
+type Action string
+
+const (
+	// ActionKept means the original value was left untouched.
+	ActionKept Action = "kept"
+	// ActionBlanked means the value was replaced with an empty string.
+	ActionBlanked Action = "blanked"
+	// ActionReplaced means the value was rewritten (e.g. UTF-8 repair).
+	ActionReplaced Action = "replaced"
+)
+
+// Entry records a single rule firing: which row and column it applied to,
+// the offending value, and what was done about it.
+type Entry struct {
+	Row    int    `json:"row"`
+	Column string `json:"column"`
+	Rule   string `json:"rule"`
+	Value  string `json:"value"`
+	Action Action `json:"action"`
+}
+
+// Report collects Entries across a pipeline run.
+type Report struct {
+	Entries []Entry
+}
+
+// Add appends e to the report. Add is a no-op on a nil *Report, so callers
+// can pass report: nil to opt out of collection entirely.
+func (r *Report) Add(e Entry) {
+	if r == nil {
+		return
+	}
+	r.Entries = append(r.Entries, e)
+}
+
+// RowCount returns the number of distinct rows represented in r.Entries. A
+// single row can trigger multiple rules (and so appear as multiple Entries),
+// so this is not simply len(r.Entries).
+func (r *Report) RowCount() int {
+	if r == nil {
+		return 0
+	}
+	rows := make(map[int]struct{}, len(r.Entries))
+	for _, e := range r.Entries {
+		rows[e.Row] = struct{}{}
+	}
+	return len(rows)
+}
+
+// WriteNDJSON writes one JSON object per Entry, newline-delimited.
+func (r *Report) WriteNDJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, e := range r.Entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
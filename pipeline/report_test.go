@@ -0,0 +1,19 @@
+package pipeline
+
+import "testing"
+
+func TestReportRowCount(t *testing.T) {
+	r := &Report{}
+	r.Add(Entry{Row: 1, Column: "Timestamp", Rule: "Timestamp", Action: ActionKept})
+	r.Add(Entry{Row: 1, Column: "ZIP", Rule: "ZIP", Action: ActionBlanked})
+	r.Add(Entry{Row: 2, Column: "Notes", Rule: "Notes", Action: ActionReplaced})
+
+	if got, want := r.RowCount(), 2; got != want {
+		t.Errorf("RowCount() = %d, want %d", got, want)
+	}
+
+	var nilReport *Report
+	if got := nilReport.RowCount(); got != 0 {
+		t.Errorf("RowCount() on nil Report = %d, want 0", got)
+	}
+}
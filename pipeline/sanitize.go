@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Mode selects how SanitizeUTF8 handles invalid byte sequences.
+type Mode int
+
+const (
+	// Replace substitutes each invalid byte with U+FFFD. This is the default.
+	Replace Mode = iota
+	// Drop removes each invalid byte instead of substituting it.
+	Drop
+	// ASCIIFold substitutes U+FFFD for each invalid byte like Replace, then
+	// decomposes (NFD) and strips combining marks, so accented letters become
+	// their base letter. Runes with no such decomposition are kept as they are,
+	// so the result is not necessarily ASCII.
+	ASCIIFold
+)
+
+// ParseMode maps a flag value ("replace", "drop", "asciifold") to a Mode.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "", "replace":
+		return Replace, nil
+	case "drop":
+		return Drop, nil
+	case "asciifold":
+		return ASCIIFold, nil
+	default:
+		return 0, fmt.Errorf("unknown UTF-8 repair mode %q (want replace, drop, or asciifold)", s)
+	}
+}
+
+// asciiFoldTransform decomposes accented characters and drops the resulting
+// combining marks, e.g. "café" -> "cafe".
+var asciiFoldTransform = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// SanitizeUTF8 repairs s so the result is always valid UTF-8. Invalid bytes
+// are handled per mode: Replace substitutes U+FFFD, Drop removes them, and
+// ASCIIFold substitutes U+FFFD and then strips combining marks from the
+// result, leaving other non-ASCII runes unchanged.
+func SanitizeUTF8(s string, mode Mode) string {
+	repaired := repairUTF8(s, mode == Drop)
+	if mode == ASCIIFold {
+		if folded, _, err := transform.String(asciiFoldTransform, repaired); err == nil {
+			return folded
+		}
+	}
+	return repaired
+}
+
+// repairUTF8 walks s rune by rune via utf8.DecodeRuneInString, substituting
+// or dropping every invalid single byte it finds.
+func repairUTF8(s string, drop bool) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			if !drop {
+				b.WriteRune(utf8.RuneError)
+			}
+			i++
+			continue
+		}
+		b.WriteRune(r)
+		i += size
+	}
+	return b.String()
+}
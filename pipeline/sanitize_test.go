@@ -0,0 +1,26 @@
+package pipeline
+
+import "testing"
+
+func TestSanitizeUTF8(t *testing.T) {
+	invalid := "caf\xe9"
+
+	tests := []struct {
+		name string
+		s    string
+		mode Mode
+		want string
+	}{
+		{"valid passthrough", "café", Replace, "café"},
+		{"replace invalid byte", invalid, Replace, "caf�"},
+		{"drop invalid byte", invalid, Drop, "caf"},
+		{"asciifold transliterates accents", "café", ASCIIFold, "cafe"},
+		{"asciifold still repairs invalid bytes", invalid, ASCIIFold, "caf�"},
+	}
+
+	for _, tt := range tests {
+		if got := SanitizeUTF8(tt.s, tt.mode); got != tt.want {
+			t.Errorf("%s: SanitizeUTF8(%q, %v) = %q, want %q", tt.name, tt.s, tt.mode, got, tt.want)
+		}
+	}
+}
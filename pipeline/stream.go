@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// RowWriter receives the header once and then each transformed data row, in
+// order. It lets RowProcessor stay agnostic of the output encoding (CSV,
+// JSON, GeoJSON, ...).
+type RowWriter interface {
+	WriteHeader(header []string) error
+	WriteRow(row []string) error
+	Flush() error
+}
+
+// csvRowWriter adapts a csv.Writer to RowWriter.
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVRowWriter wraps w as a RowWriter.
+func NewCSVRowWriter(w *csv.Writer) RowWriter {
+	return &csvRowWriter{w: w}
+}
+
+func (c *csvRowWriter) WriteHeader(header []string) error { return c.w.Write(header) }
+func (c *csvRowWriter) WriteRow(row []string) error       { return c.w.Write(row) }
+
+func (c *csvRowWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// RowProcessor streams records through a Pipeline one row at a time, so
+// memory use is O(columns) rather than O(rows * stages).
+type RowProcessor struct {
+	pipeline *Pipeline
+}
+
+// NewRowProcessor wraps a Pipeline for streaming use.
+func NewRowProcessor(p *Pipeline) *RowProcessor {
+	return &RowProcessor{pipeline: p}
+}
+
+// Run reads the header and then each record from r, applies every
+// transformer to it in order, and writes it to w immediately. Once every row
+// is written it flushes w; a read, transform or write error returns without
+// flushing. report may be nil if the caller doesn't want a Report.
+func (rp *RowProcessor) Run(r *csv.Reader, w RowWriter, report *Report) error {
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+
+	fns, err := rp.pipeline.Bind(header)
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteHeader(header); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	rowNum := 0
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading row: %w", err)
+		}
+		rowNum++
+
+		for i, fn := range fns {
+			if err := fn(row, rowNum, report); err != nil {
+				return fmt.Errorf("%s: %w", rp.pipeline.Transformers[i].Name(), err)
+			}
+		}
+
+		if err := w.WriteRow(row); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+
+	return w.Flush()
+}
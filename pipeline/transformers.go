@@ -0,0 +1,399 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultTimestampLayouts are tried in order until one parses; US-style
+// slash dates are listed first since that's the most common export format.
+var defaultTimestampLayouts = []string{
+	"1/2/06 3:04:05 PM",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	time.RFC1123,
+}
+
+// columnIndex returns the index of name within header, matching
+// case-insensitively, or -1 if it isn't present.
+func columnIndex(header []string, name string) int {
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// TimestampTransformer converts a timestamp column from SourceTZ to TargetTZ,
+// reformatting it as RFC3339. Layouts are tried in order against each value;
+// if empty, defaultTimestampLayouts is used.
+type TimestampTransformer struct {
+	Column   string
+	SourceTZ string
+	TargetTZ string
+	Layouts  []string
+}
+
+func (t *TimestampTransformer) Name() string { return "timestamp" }
+
+func (t *TimestampTransformer) Bind(header []string) (RowFunc, error) {
+	idx := columnIndex(header, t.Column)
+	if idx == -1 {
+		return nil, fmt.Errorf("%s column not found", t.Column)
+	}
+
+	layouts := t.Layouts
+	if len(layouts) == 0 {
+		layouts = defaultTimestampLayouts
+	}
+
+	locSource, err := time.LoadLocation(t.SourceTZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load source timezone: %v", err)
+	}
+	locTarget, err := time.LoadLocation(t.TargetTZ)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load target timezone: %v", err)
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		if idx >= len(row) {
+			return nil
+		}
+		parsed, err := parseTimestamp(row[idx], layouts, locSource)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			report.Add(Entry{Row: rowNum, Column: t.Column, Rule: t.Name(), Value: row[idx], Action: ActionKept})
+			return nil
+		}
+		row[idx] = parsed.In(locTarget).Format(time.RFC3339)
+		return nil
+	}, nil
+}
+
+// parseTimestamp tries each layout in turn, returning the first successful
+// parse. If none match, it returns a TimestampFormatError naming the
+// candidates that were tried.
+func parseTimestamp(value string, layouts []string, loc *time.Location) (time.Time, error) {
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, &TimestampFormatError{Value: value, Layouts: layouts}
+}
+
+// TimestampFormatError reports that a timestamp value didn't match any of
+// the candidate layouts tried, so callers can distinguish a bad value from
+// a misconfigured timezone.
+type TimestampFormatError struct {
+	Value   string
+	Layouts []string
+}
+
+func (e *TimestampFormatError) Error() string {
+	return fmt.Sprintf("could not parse timestamp %q against any of %d candidate layouts", e.Value, len(e.Layouts))
+}
+
+// ZIPTransformer pads all numeric ZIP codes to Width digits.
+type ZIPTransformer struct {
+	Column string
+	Width  int
+}
+
+func (z *ZIPTransformer) Name() string { return "zip" }
+
+func (z *ZIPTransformer) Bind(header []string) (RowFunc, error) {
+	idx := columnIndex(header, z.Column)
+	if idx == -1 {
+		return nil, fmt.Errorf("%s column not found", z.Column)
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		if idx >= len(row) {
+			return nil
+		}
+		zip := strings.TrimSpace(row[idx])
+		if zip == "" {
+			return nil
+		}
+		if _, err := strconv.Atoi(zip); err == nil {
+			row[idx] = fmt.Sprintf("%0*s", z.Width, zip)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: invalid ZIP value %q\n", zip)
+			report.Add(Entry{Row: rowNum, Column: z.Column, Rule: z.Name(), Value: zip, Action: ActionKept})
+		}
+		return nil
+	}, nil
+}
+
+// FirstNameTransformer adjusts the case of the first word of Column.
+// Case may be "upper", "lower", or "title"; it defaults to "upper".
+type FirstNameTransformer struct {
+	Column string
+	Case   string
+}
+
+func (f *FirstNameTransformer) Name() string { return "firstname" }
+
+func (f *FirstNameTransformer) Bind(header []string) (RowFunc, error) {
+	idx := columnIndex(header, f.Column)
+	if idx == -1 {
+		return nil, fmt.Errorf("%s column not found", f.Column)
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		if idx >= len(row) {
+			return nil
+		}
+		original := strings.TrimSpace(row[idx])
+		if original == "" {
+			return nil
+		}
+		parts := strings.Fields(original)
+		if len(parts) > 0 {
+			parts[0] = applyCase(parts[0], f.Case)
+			row[idx] = strings.Join(parts, " ")
+		}
+		return nil
+	}, nil
+}
+
+func applyCase(word, mode string) string {
+	switch mode {
+	case "lower":
+		return strings.ToLower(word)
+	case "title":
+		runes := []rune(word)
+		if len(runes) == 0 {
+			return word
+		}
+		return strings.ToUpper(string(runes[:1])) + strings.ToLower(string(runes[1:]))
+	default:
+		return strings.ToUpper(word)
+	}
+}
+
+// AddressTransformer repairs invalid UTF-8 in Column per Mode.
+type AddressTransformer struct {
+	Column string
+	Mode   Mode
+}
+
+func (a *AddressTransformer) Name() string { return "address" }
+
+func (a *AddressTransformer) Bind(header []string) (RowFunc, error) {
+	idx := columnIndex(header, a.Column)
+	if idx == -1 {
+		return nil, fmt.Errorf("%s column not found", a.Column)
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		if idx >= len(row) {
+			return nil
+		}
+		if !utf8.ValidString(row[idx]) {
+			fmt.Fprintf(os.Stderr, "Warning: repairing invalid UTF-8 in %s: %q\n", a.Column, row[idx])
+			original := row[idx]
+			row[idx] = SanitizeUTF8(row[idx], a.Mode)
+			report.Add(Entry{Row: rowNum, Column: a.Column, Rule: a.Name(), Value: original, Action: ActionReplaced})
+		}
+		return nil
+	}, nil
+}
+
+// DurationsTransformer converts each of Columns from HH:MM:SS.MS to float seconds.
+type DurationsTransformer struct {
+	Columns []string
+}
+
+func (d *DurationsTransformer) Name() string { return "durations" }
+
+func (d *DurationsTransformer) Bind(header []string) (RowFunc, error) {
+	indices := make([]int, len(d.Columns))
+	for i, col := range d.Columns {
+		idx := columnIndex(header, col)
+		if idx == -1 {
+			return nil, fmt.Errorf("%s column not found", col)
+		}
+		indices[i] = idx
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		for i, idx := range indices {
+			if idx >= len(row) {
+				continue
+			}
+			sec, err := parseHHMMSS(row[idx])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not parse %s %q: %v\n", d.Columns[i], row[idx], err)
+				report.Add(Entry{Row: rowNum, Column: d.Columns[i], Rule: d.Name(), Value: row[idx], Action: ActionKept})
+				continue
+			}
+			row[idx] = fmt.Sprintf("%.3f", sec)
+		}
+		return nil
+	}, nil
+}
+
+// isoDurationPattern matches ISO-8601 durations of the form P(nH)?(nM)?(nS)?,
+// e.g. PT1H30M15.5S. Go's own time.ParseDuration doesn't accept this family.
+var isoDurationPattern = regexp.MustCompile(`^PT?(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?$`)
+
+// parseHHMMSS parses a duration in either HH:MM:SS.MS or ISO-8601
+// (PnHnMnS) format and returns total seconds.
+func parseHHMMSS(input string) (float64, error) {
+	if strings.HasPrefix(input, "P") {
+		return parseISODuration(input)
+	}
+	return parseClockDuration(input)
+}
+
+// parseClockDuration parses a duration in HH:MM:SS.MS format.
+func parseClockDuration(input string) (float64, error) {
+	parts := strings.Split(input, ":")
+	if len(parts) != 3 {
+		return 0, &DurationFormatError{Format: "hhmmss", Value: input, Err: fmt.Errorf("expected HH:MM:SS.MS")}
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, &DurationFormatError{Format: "hhmmss", Value: input, Err: fmt.Errorf("invalid hours: %w", err)}
+	}
+
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, &DurationFormatError{Format: "hhmmss", Value: input, Err: fmt.Errorf("invalid minutes: %w", err)}
+	}
+
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, &DurationFormatError{Format: "hhmmss", Value: input, Err: fmt.Errorf("invalid seconds: %w", err)}
+	}
+
+	total := float64(hours*3600 + minutes*60)
+	return total + seconds, nil
+}
+
+// parseISODuration parses an ISO-8601 duration of the form P(nH)?(nM)?(nS)?.
+func parseISODuration(input string) (float64, error) {
+	match := isoDurationPattern.FindStringSubmatch(input)
+	if match == nil || (match[1] == "" && match[2] == "" && match[3] == "") {
+		return 0, &DurationFormatError{Format: "iso8601", Value: input, Err: fmt.Errorf("expected P(nH)?(nM)?(nS)?")}
+	}
+
+	var total float64
+	for i, unit := range [3]float64{3600, 60, 1} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, &DurationFormatError{Format: "iso8601", Value: input, Err: err}
+		}
+		total += n * unit
+	}
+	return total, nil
+}
+
+// DurationFormatError reports that a duration value didn't match the
+// expected format, identifying which format family (hhmmss or iso8601) was
+// attempted so callers can distinguish the two failure modes.
+type DurationFormatError struct {
+	Format string
+	Value  string
+	Err    error
+}
+
+func (e *DurationFormatError) Error() string {
+	return fmt.Sprintf("invalid %s duration %q: %v", e.Format, e.Value, e.Err)
+}
+
+func (e *DurationFormatError) Unwrap() error { return e.Err }
+
+// TotalDurationTransformer replaces Output with the sum of Columns.
+type TotalDurationTransformer struct {
+	Columns []string
+	Output  string
+}
+
+func (t *TotalDurationTransformer) Name() string { return "total_duration" }
+
+func (t *TotalDurationTransformer) Bind(header []string) (RowFunc, error) {
+	indices := make([]int, len(t.Columns))
+	for i, col := range t.Columns {
+		idx := columnIndex(header, col)
+		if idx == -1 {
+			return nil, fmt.Errorf("%s column not found", col)
+		}
+		indices[i] = idx
+	}
+	outIdx := columnIndex(header, t.Output)
+	if outIdx == -1 {
+		return nil, fmt.Errorf("%s column not found", t.Output)
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		if outIdx >= len(row) {
+			return nil
+		}
+
+		var total float64
+		ok := true
+		for _, idx := range indices {
+			if idx >= len(row) {
+				ok = false
+				break
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(row[idx]), 64)
+			if err != nil {
+				ok = false
+				break
+			}
+			total += v
+		}
+
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: failed to parse durations for row: %v\n", row)
+			report.Add(Entry{Row: rowNum, Column: t.Output, Rule: t.Name(), Value: row[outIdx], Action: ActionBlanked})
+			row[outIdx] = ""
+		} else {
+			row[outIdx] = fmt.Sprintf("%.3f", total)
+		}
+		return nil
+	}, nil
+}
+
+// NotesTransformer repairs invalid UTF-8 in Column per Mode.
+type NotesTransformer struct {
+	Column string
+	Mode   Mode
+}
+
+func (n *NotesTransformer) Name() string { return "notes" }
+
+func (n *NotesTransformer) Bind(header []string) (RowFunc, error) {
+	idx := columnIndex(header, n.Column)
+	if idx == -1 {
+		return nil, fmt.Errorf("%s column not found", n.Column)
+	}
+
+	return func(row []string, rowNum int, report *Report) error {
+		if idx >= len(row) {
+			return nil
+		}
+		if !utf8.ValidString(row[idx]) {
+			fmt.Fprintf(os.Stderr, "Warning: repairing invalid UTF-8 in %s: %q\n", n.Column, row[idx])
+			original := row[idx]
+			row[idx] = SanitizeUTF8(row[idx], n.Mode)
+			report.Add(Entry{Row: rowNum, Column: n.Column, Rule: n.Name(), Value: original, Action: ActionReplaced})
+		}
+		return nil
+	}, nil
+}
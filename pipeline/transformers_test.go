@@ -0,0 +1,82 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyCase(t *testing.T) {
+	tests := []struct {
+		word string
+		mode string
+		want string
+	}{
+		{"john", "upper", "JOHN"},
+		{"JOHN", "lower", "john"},
+		{"john", "title", "John"},
+		{"álvaro", "title", "Álvaro"},
+		{"Łukasz", "title", "Łukasz"},
+		{"", "title", ""},
+	}
+
+	for _, tt := range tests {
+		if got := applyCase(tt.word, tt.mode); got != tt.want {
+			t.Errorf("applyCase(%q, %q) = %q, want %q", tt.word, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	got, err := parseTimestamp("1/2/06 3:04:05 PM", defaultTimestampLayouts, loc)
+	if err != nil {
+		t.Fatalf("parseTimestamp returned error: %v", err)
+	}
+	want := time.Date(2006, time.January, 2, 15, 4, 5, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("parseTimestamp = %v, want %v", got, want)
+	}
+
+	got, err = parseTimestamp("2006-01-02 15:04:05", defaultTimestampLayouts, loc)
+	if err != nil {
+		t.Fatalf("parseTimestamp returned error: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("parseTimestamp = %v, want %v", got, want)
+	}
+
+	if _, err := parseTimestamp("not a timestamp", defaultTimestampLayouts, loc); err == nil {
+		t.Error("parseTimestamp returned nil error for an unparseable value")
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"PT1H30M15.5S", 5415.5},
+		{"PT45M", 2700},
+		{"PT10S", 10},
+		{"PT2H", 7200},
+	}
+
+	for _, tt := range tests {
+		got, err := parseISODuration(tt.input)
+		if err != nil {
+			t.Errorf("parseISODuration(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseISODuration(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := parseISODuration("not a duration"); err == nil {
+		t.Error("parseISODuration returned nil error for an unparseable value")
+	}
+}